@@ -1,25 +1,76 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"math"
 	"os"
+	"time"
+
 	"quack/pkg/analog"
-	"sync"
+	"quack/pkg/analog/embed"
+	"quack/pkg/analog/solver"
+	"quack/pkg/pipeline"
+	"quack/pkg/pulser/client"
+	"quack/pkg/utils"
 )
 
-// GraphInput represents the JSON structure for graph input.
+// defaultDeviceLimits are conservative placeholder limits used to validate
+// a sequence before submission. Real limits vary by backend and device;
+// until the CLI fetches them from backend metadata, these stand in as a
+// sanity check against obviously unrealizable sequences.
+var defaultDeviceLimits = utils.DeviceLimits{
+	MaxAmplitude:     4 * math.Pi,
+	MaxSlope:         0.01,
+	ClockPeriod:      1,
+	MaxTotalDuration: 1_000_000,
+}
+
+// GraphInput represents the JSON structure for graph input. It describes
+// either a (possibly weighted) graph for -h/-j MIS compilation, or a raw
+// QUBO problem (when the qubo field is present) compiled via
+// analog.CompileQUBO instead.
 type GraphInput struct {
-	Vertices int      `json:"vertices"`
-	Edges    [][2]int `json:"edges"`
+	Vertices      int               `json:"vertices"`
+	Edges         [][2]int          `json:"edges"`
+	VertexWeights []float64         `json:"vertex_weights,omitempty"`
+	EdgeWeights   []EdgeWeightEntry `json:"edge_weights,omitempty"`
+	QUBO          []QUBOTerm        `json:"qubo,omitempty"`
+}
+
+// EdgeWeightEntry assigns a weight to one edge of a GraphInput.
+type EdgeWeightEntry struct {
+	Edge   [2]int  `json:"edge"`
+	Weight float64 `json:"weight"`
 }
 
-// ProcessingResult represents the result of processing a file
-type ProcessingResult struct {
-	FilePath   string
-	IsingModel analog.IsingModel
-	Error      error
+// QUBOTerm is one entry of a GraphInput's qubo field: the linear term Q_ii
+// when I == J, otherwise the quadratic term Q_ij.
+type QUBOTerm struct {
+	I     int     `json:"i"`
+	J     int     `json:"j"`
+	Value float64 `json:"value"`
+}
+
+// FileGraph carries a parsed, validated graph alongside the file it came
+// from, so later stages can still report errors against a path. QUBO is
+// set instead of Graph's edges being MIS-compiled when the input file had
+// a qubo field; Graph.Vertices is populated either way.
+type FileGraph struct {
+	FilePath string
+	Graph    analog.Graph
+	QUBO     map[[2]int]float64
+}
+
+// FileModel carries a compiled Ising model alongside its source file.
+// Offset is the constant energy offset CompileQUBO introduces; it is zero
+// for models compiled via CompileMIS.
+type FileModel struct {
+	FilePath string
+	Model    analog.IsingModel
+	Offset   float64
 }
 
 // main is the entry point for the CLI tool of the analog quantum compiler.
@@ -33,12 +84,24 @@ func main() {
 	h := flag.Float64("h", -1.0, "External field for the Ising model (typically negative)")
 	j := flag.Float64("j", 2.0, "Interaction strength for the Ising model (typically positive)")
 	jsonOutput := flag.Bool("json", false, "Output the Ising model in JSON format")
+	workers := flag.Int("workers", 4, "Number of concurrent workers per pipeline stage")
+	submit := flag.Bool("submit", false, "Submit the compiled model as a Pulser sequence and print the measurement results")
+	backend := flag.String("backend", string(client.BackendMock), "Execution backend for -submit: mock, pasqal-cloud, or azure-quantum")
+	apiKey := flag.String("api-key", "", "API key for -backend=pasqal-cloud")
+	clientID := flag.String("client-id", "", "OAuth client ID for -backend=azure-quantum")
+	clientSecret := flag.String("client-secret", "", "OAuth client secret for -backend=azure-quantum")
+	tenantID := flag.String("tenant-id", "", "Azure AD tenant ID for -backend=azure-quantum")
+	solve := flag.Bool("solve", false, "Exactly diagonalize the compiled model and print its ground-state MIS solution")
+	embedHardware := flag.Bool("embed", false, "With -submit, embed the Ising model onto 2D atom positions via the Rydberg blockade instead of using a placeholder layout")
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "This tool compiles graphs into Ising models for analog neutral atom quantum computers.\n")
 		fmt.Fprintf(os.Stderr, "Example: %s -input=graph1.json -input=graph2.json -h=-1.0 -j=2.0\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Expected JSON format for input file:\n")
-		fmt.Fprintf(os.Stderr, `{"vertices": 4, "edges": [[0,1], [1,2], [2,3], [3,0]]}`+"\n\n")
+		fmt.Fprintf(os.Stderr, `{"vertices": 4, "edges": [[0,1], [1,2], [2,3], [3,0]]}`+"\n")
+		fmt.Fprintf(os.Stderr, "Vertex/edge weights and a raw QUBO problem are also accepted:\n")
+		fmt.Fprintf(os.Stderr, `{"vertices": 2, "edges": [[0,1]], "vertex_weights": [1, 2], "edge_weights": [{"edge": [0,1], "weight": 0.5}]}`+"\n")
+		fmt.Fprintf(os.Stderr, `{"vertices": 2, "qubo": [{"i": 0, "j": 0, "value": -1}, {"i": 0, "j": 1, "value": 2}]}`+"\n\n")
 		flag.PrintDefaults()
 	}
 	flag.Parse()
@@ -60,99 +123,228 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Channels for pipeline
-	fileChan := make(chan string, len(inputFiles))
-	graphChan := make(chan analog.Graph, len(inputFiles))
-	resultChan := make(chan ProcessingResult, len(inputFiles))
+	ctx := context.Background()
+	opts := pipeline.Options{Workers: *workers}
+	runner := pipeline.NewRunner()
 
-	// WaitGroup for synchronization
-	var wg sync.WaitGroup
-	wg.Add(3) // One for each stage: read, validate, compile
+	fileChan := make(chan string, len(inputFiles))
+	for _, f := range inputFiles {
+		fileChan <- f
+	}
+	close(fileChan)
 
-	// Start goroutines
-	go readFiles(inputFiles, fileChan, &wg)
-	go processGraphs(fileChan, graphChan, resultChan, &wg)
-	go compileGraphs(graphChan, resultChan, *h, *j, &wg)
+	readStage := pipeline.StageFunc[string, FileGraph]{
+		StageName: "read-validate",
+		Fn: func(ctx context.Context, filePath string) (FileGraph, error) {
+			graph, qubo, err := readGraphFromJSON(filePath)
+			if err != nil {
+				return FileGraph{}, err
+			}
+			if qubo == nil {
+				if err := validateGraph(graph); err != nil {
+					return FileGraph{}, err
+				}
+			}
+			return FileGraph{FilePath: filePath, Graph: graph, QUBO: qubo}, nil
+		},
+	}
+	graphResults, graphMetrics := pipeline.RunStage[string, FileGraph](ctx, fileChan, readStage, opts)
+	runner.Register(graphMetrics)
 
-	// Collect results in a separate goroutine
+	graphChan := make(chan FileGraph, opts.BufferSize)
 	go func() {
-		for result := range resultChan {
-			if result.Error != nil {
-				fmt.Fprintf(os.Stderr, "Error processing %s: %v\n", result.FilePath, result.Error)
+		defer close(graphChan)
+		for result := range graphResults {
+			if result.Err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading graph: %v\n", result.Err)
 				continue
 			}
-			if *jsonOutput {
-				output, err := json.MarshalIndent(result.IsingModel, "", "  ")
+			graphChan <- result.Value
+		}
+	}()
+
+	compileStage := pipeline.StageFunc[FileGraph, FileModel]{
+		StageName: "compile",
+		Fn: func(ctx context.Context, fg FileGraph) (FileModel, error) {
+			if fg.QUBO != nil {
+				model, offset, err := analog.CompileQUBO(fg.QUBO, fg.Graph.Vertices)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error generating JSON output for %s: %v\n", result.FilePath, err)
-					continue
+					return FileModel{}, err
 				}
-				fmt.Printf("Result for %s:\n%s\n", result.FilePath, string(output))
-			} else {
-				fmt.Printf("Result for %s:\nIsing Model: %+v\n", result.FilePath, result.IsingModel)
+				return FileModel{FilePath: fg.FilePath, Model: model, Offset: offset}, nil
 			}
+			return FileModel{FilePath: fg.FilePath, Model: analog.CompileMIS(fg.Graph, *h, *j)}, nil
+		},
+	}
+	modelResults, compileMetrics := pipeline.RunStage[FileGraph, FileModel](ctx, graphChan, compileStage, opts)
+	runner.Register(compileMetrics)
+
+	for result := range modelResults {
+		if result.Err != nil {
+			fmt.Fprintf(os.Stderr, "Error compiling graph: %v\n", result.Err)
+			continue
 		}
-	}()
+		printModel(result.Value, *jsonOutput)
 
-	// Wait for all goroutines to complete
-	wg.Wait()
-	close(resultChan) // Close result channel after all processing is done
+		if *submit {
+			cfg := client.Config{
+				Backend:      client.Backend(*backend),
+				APIKey:       *apiKey,
+				ClientID:     *clientID,
+				ClientSecret: *clientSecret,
+				TenantID:     *tenantID,
+			}
+			if err := submitAndPrint(result.Value, cfg, *embedHardware); err != nil {
+				fmt.Fprintf(os.Stderr, "Error submitting %s: %v\n", result.Value.FilePath, err)
+			}
+		}
 
-	fmt.Println("Compilation completed.")
-}
+		if *solve {
+			if err := solveAndPrint(result.Value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error solving %s: %v\n", result.Value.FilePath, err)
+			}
+		}
+	}
 
-// readFiles sends input file paths to the file channel
-func readFiles(inputFiles []string, fileChan chan<- string, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for _, filePath := range inputFiles {
-		fileChan <- filePath
+	for _, line := range runner.Summary() {
+		fmt.Println(line)
 	}
-	close(fileChan)
+	fmt.Println("Compilation completed.")
 }
 
-// processGraphs reads and validates graphs
-func processGraphs(fileChan <-chan string, graphChan chan<- analog.Graph, resultChan chan<- ProcessingResult, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for filePath := range fileChan {
-		graph, err := readGraphFromJSON(filePath)
+// printModel prints a compiled Ising model, as JSON if requested.
+func printModel(fm FileModel, asJSON bool) {
+	if asJSON {
+		output, err := json.MarshalIndent(fm.Model, "", "  ")
 		if err != nil {
-			resultChan <- ProcessingResult{FilePath: filePath, Error: err}
-			continue
+			fmt.Fprintf(os.Stderr, "Error generating JSON output for %s: %v\n", fm.FilePath, err)
+			return
 		}
-		if err := validateGraph(graph); err != nil {
-			resultChan <- ProcessingResult{FilePath: filePath, Error: err}
-			continue
-		}
-		graphChan <- graph
+		fmt.Printf("Result for %s:\n%s\n", fm.FilePath, string(output))
+		return
 	}
-	close(graphChan)
-}
-
-// compileGraphs compiles graphs into Ising models
-func compileGraphs(graphChan <-chan analog.Graph, resultChan chan<- ProcessingResult, h, j float64, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for graph := range graphChan {
-		isingModel := analog.CompileMIS(graph, h, j)
-		resultChan <- ProcessingResult{FilePath: fmt.Sprintf("graph_%d", graph.Vertices), IsingModel: isingModel}
+	fmt.Printf("Result for %s:\nIsing Model: %+v\n", fm.FilePath, fm.Model)
+	if fm.Offset != 0 {
+		fmt.Printf("  QUBO offset: %.4f\n", fm.Offset)
 	}
 }
 
-// readGraphFromJSON reads and parses the graph from a JSON file.
-func readGraphFromJSON(filePath string) (analog.Graph, error) {
+// readGraphFromJSON reads and parses a GraphInput from a JSON file. The
+// second return value is non-nil when the file carries a qubo field, in
+// which case the caller should compile it with analog.CompileQUBO instead
+// of treating the first value as an MIS graph.
+func readGraphFromJSON(filePath string) (analog.Graph, map[[2]int]float64, error) {
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
-		return analog.Graph{}, fmt.Errorf("failed to read file %s: %v", filePath, err)
+		return analog.Graph{}, nil, fmt.Errorf("failed to read file %s: %v", filePath, err)
 	}
 
 	var graphInput GraphInput
 	if err := json.Unmarshal(fileContent, &graphInput); err != nil {
-		return analog.Graph{}, fmt.Errorf("failed to parse JSON: %v", err)
+		return analog.Graph{}, nil, fmt.Errorf("failed to parse JSON: %v", err)
 	}
 
-	return analog.Graph{
+	graph := analog.Graph{
 		Vertices: graphInput.Vertices,
 		Edges:    graphInput.Edges,
-	}, nil
+	}
+	if len(graphInput.VertexWeights) > 0 {
+		graph.VertexWeight = graphInput.VertexWeights
+	}
+	if len(graphInput.EdgeWeights) > 0 {
+		graph.EdgeWeight = make(map[[2]int]float64, len(graphInput.EdgeWeights))
+		for _, ew := range graphInput.EdgeWeights {
+			i, j := ew.Edge[0], ew.Edge[1]
+			if i > j {
+				i, j = j, i
+			}
+			graph.EdgeWeight[[2]int{i, j}] = ew.Weight
+		}
+	}
+
+	if len(graphInput.QUBO) == 0 {
+		return graph, nil, nil
+	}
+
+	qubo := make(map[[2]int]float64, len(graphInput.QUBO))
+	for _, t := range graphInput.QUBO {
+		i, j := t.I, t.J
+		if i > j {
+			i, j = j, i
+		}
+		qubo[[2]int{i, j}] = t.Value
+	}
+	return graph, qubo, nil
+}
+
+// submitAndPrint builds a Pulser sequence for fm's Ising model, submits it
+// to cfg.Backend, waits for it to complete, and prints the measurement
+// counts. When useEmbedding is set the sequence is built by embedding the
+// model onto hardware-realizable atom positions; otherwise a placeholder
+// line layout is used.
+func submitAndPrint(fm FileModel, cfg client.Config, useEmbedding bool) error {
+	c, err := client.New(cfg)
+	if err != nil {
+		return err
+	}
+
+	seq, err := client.NaiveSequence(fm.Model.Spins)
+	if err != nil {
+		return fmt.Errorf("build sequence: %w", err)
+	}
+	if useEmbedding {
+		embedded, report, err := embed.EmbedAndBuildSequence(fm.Model, embed.SweepParams{
+			RiseDuration: 1000, HoldDuration: 2000, FallDuration: 1000,
+			StartDetuning: -10, FinalDetuning: 10,
+		})
+		if err != nil {
+			return fmt.Errorf("embed: %w", err)
+		}
+		for _, w := range report.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning embedding %s: %s\n", fm.FilePath, w)
+		}
+		seq = embedded
+	}
+
+	if err := seq.Validate(defaultDeviceLimits); err != nil {
+		return fmt.Errorf("validate sequence: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	results, err := client.Run(ctx, c, seq, time.Second)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Job %s results for %s: %d shots over %d distinct outcomes\n", results.JobID, fm.FilePath, results.Shots, len(results.Counts))
+	for bitstring, count := range results.Counts {
+		fmt.Printf("  %s: %d\n", bitstring, count)
+	}
+	return nil
+}
+
+// solveAndPrint exactly diagonalizes fm's Ising model and prints the
+// ground-state energy, degeneracy, and the MIS decoded from the most likely
+// ground-state basis state.
+func solveAndPrint(fm FileModel) error {
+	solution, err := solver.Solve(fm.Model, solver.SolverOptions{})
+	if err != nil {
+		return err
+	}
+
+	best := 0
+	for state, p := range solution.Probabilities {
+		if p > solution.Probabilities[best] {
+			best = state
+		}
+	}
+	set := analog.DecodeMIS(best, fm.Model.Spins)
+
+	fmt.Printf("Ground state for %s: energy=%.4f degeneracy=%d\n", fm.FilePath, solution.GroundEnergy, solution.Degeneracy)
+	fmt.Printf("  MIS: %v\n", set)
+	return nil
 }
 
 // validateGraph checks the validity of the graph data.
@@ -3,6 +3,7 @@ package analog
 // CompileMIS compiles a graph into an Ising model for the Maximum Independent Set problem.
 // The parameter h is the external field (typically negative to encourage spins in +1 state),
 // and j is the interaction strength (typically positive to penalize adjacent spins both in +1 state).
+// When graph carries vertex or edge weights (for weighted-MIS), h and j are scaled by them.
 func CompileMIS(graph Graph, h, j float64) IsingModel {
 	// Initialize the Ising model
 	ising := IsingModel{
@@ -13,7 +14,7 @@ func CompileMIS(graph Graph, h, j float64) IsingModel {
 
 	// Set external field h_i for each spin to encourage +1 state (maximize set size)
 	for i := 0; i < graph.Vertices; i++ {
-		ising.ExternalField[i] = h
+		ising.ExternalField[i] = h * graph.VertexWeightOf(i)
 	}
 
 	// Set interaction strengths J_ij for edges to penalize adjacent spins both in +1 state
@@ -29,7 +30,7 @@ func CompileMIS(graph Graph, h, j float64) IsingModel {
 		if _, exists := ising.Interactions[key]; exists {
 			return IsingModel{} // Return empty model to indicate error
 		}
-		ising.Interactions[key] = j
+		ising.Interactions[key] = j * graph.EdgeWeightOf(key[0], key[1])
 	}
 
 	return ising
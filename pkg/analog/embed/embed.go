@@ -0,0 +1,188 @@
+// Package embed bridges pkg/analog and pkg/utils: it compiles an
+// analog.IsingModel into 2D atom positions that realize its J_ij couplings
+// through the Rydberg C6/r^6 blockade, and wraps them in a Pulser
+// SequenceBuilder implementing an adiabatic MIS sweep.
+package embed
+
+import (
+	"fmt"
+	"math"
+
+	"quack/pkg/analog"
+	"quack/pkg/utils"
+)
+
+// SweepParams configures the adiabatic rise/hold/fall sweep built by
+// EmbedAndBuildSequence. Durations are in nanoseconds, matching Pulser's
+// convention.
+type SweepParams struct {
+	RiseDuration int
+	HoldDuration int
+	FallDuration int
+
+	MaxAmplitude   float64 // rad/us, reached at the end of the rise
+	StartDetuning  float64 // MHz, at the start of the rise
+	FinalDetuning  float64 // MHz, reached at the end of the fall
+	MinAtomSpacing float64 // micrometers; defaults to 4.0 if zero
+
+	// StressIterations bounds how many stress-majorization passes refine
+	// the atom layout; defaults to 200 if zero.
+	StressIterations int
+
+	// ErrorTolerance is the maximum allowed relative embedding error (RMS
+	// over edges of |actual_r - target_r| / target_r) before
+	// EmbeddingReport.Warnings gets a note; defaults to 0.1 if zero.
+	ErrorTolerance float64
+}
+
+func (p SweepParams) withDefaults() SweepParams {
+	if p.MinAtomSpacing == 0 {
+		p.MinAtomSpacing = 4.0
+	}
+	if p.StressIterations == 0 {
+		p.StressIterations = 200
+	}
+	if p.ErrorTolerance == 0 {
+		p.ErrorTolerance = 0.1
+	}
+	if p.MaxAmplitude == 0 {
+		p.MaxAmplitude = 2 * 3.141592653589793
+	}
+	return p
+}
+
+// EmbeddingReport describes how well the computed atom layout realized the
+// model's target couplings.
+type EmbeddingReport struct {
+	// RMSRelativeError is the root-mean-square, over all interacting edges,
+	// of the relative error between the actual and target inter-atom
+	// distance.
+	RMSRelativeError float64
+
+	// MinAtomDistance is the smallest distance between any two atoms in
+	// the final layout.
+	MinAtomDistance float64
+
+	// Warnings lists any issues found, e.g. embedding error above
+	// tolerance; empty when the embedding is clean.
+	Warnings []string
+}
+
+// EmbedAndBuildSequence compiles model into 2D atom positions realizing its
+// couplings and wraps them in a SequenceBuilder driving an adiabatic MIS
+// sweep: amplitude rises from 0 to MaxAmplitude, holds, then falls back to
+// 0, while detuning ramps linearly from StartDetuning to FinalDetuning over
+// the whole sequence.
+func EmbedAndBuildSequence(model analog.IsingModel, params SweepParams) (utils.SequenceBuilder, EmbeddingReport, error) {
+	params = params.withDefaults()
+	n := model.Spins
+	if n <= 0 {
+		return utils.SequenceBuilder{}, EmbeddingReport{}, fmt.Errorf("embed: model has no spins")
+	}
+
+	target := make(map[[2]int]float64, len(model.Interactions))
+	var negativeCouplings [][2]int
+	for edge, j := range model.Interactions {
+		if j == 0 {
+			continue
+		}
+		if j < 0 {
+			negativeCouplings = append(negativeCouplings, edge)
+		}
+		target[edge] = targetDistance(j, defaultC6)
+	}
+
+	points := initialLayout(n, params.MinAtomSpacing*2)
+	points = stressMajorization(points, target, params.StressIterations)
+	points = enforceMinDistance(points, params.MinAtomSpacing)
+
+	report := buildReport(points, target, params)
+	for _, edge := range negativeCouplings {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("interaction %v has a negative J_ij; the Rydberg blockade only realizes repulsive (positive) couplings, so this edge is embedded at the distance for |J_ij| with the wrong physical sign", edge))
+	}
+
+	reg := utils.Register{}
+	reg.Qubits.Positions = make(map[string][]float64, n)
+	for i, p := range points {
+		reg.Qubits.Positions[fmt.Sprintf("q%d", i)] = []float64{p.x, p.y}
+	}
+
+	seq := utils.SequenceBuilder{Register: reg}
+	if err := seq.DeclareChannel("ch0", "rydberg_global"); err != nil {
+		return utils.SequenceBuilder{}, EmbeddingReport{}, err
+	}
+	if err := addSweepPulses(&seq, params); err != nil {
+		return utils.SequenceBuilder{}, EmbeddingReport{}, err
+	}
+
+	return seq, report, nil
+}
+
+func buildReport(points []point, target map[[2]int]float64, params SweepParams) EmbeddingReport {
+	var report EmbeddingReport
+
+	var sumSquaredError float64
+	for edge, d := range target {
+		actual := points[edge[0]].distanceTo(points[edge[1]])
+		relErr := (actual - d) / d
+		sumSquaredError += relErr * relErr
+	}
+	if len(target) > 0 {
+		report.RMSRelativeError = math.Sqrt(sumSquaredError / float64(len(target)))
+	}
+	if report.RMSRelativeError > params.ErrorTolerance {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("embedding RMS relative error %.3f exceeds tolerance %.3f", report.RMSRelativeError, params.ErrorTolerance))
+	}
+
+	report.MinAtomDistance = minPairwiseDistance(points)
+	if report.MinAtomDistance < params.MinAtomSpacing-1e-6 {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("minimum atom distance %.3f is below the requested spacing %.3f", report.MinAtomDistance, params.MinAtomSpacing))
+	}
+
+	return report
+}
+
+func minPairwiseDistance(points []point) float64 {
+	min := -1.0
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			d := points[i].distanceTo(points[j])
+			if min < 0 || d < min {
+				min = d
+			}
+		}
+	}
+	if min < 0 {
+		return 0
+	}
+	return min
+}
+
+// addSweepPulses adds the rise/hold/fall amplitude ramp and the matching
+// linear detuning ramp for an adiabatic MIS sweep to seq.
+func addSweepPulses(seq *utils.SequenceBuilder, params SweepParams) error {
+	totalDuration := params.RiseDuration + params.HoldDuration + params.FallDuration
+	detuningSlope := (params.FinalDetuning - params.StartDetuning) / float64(totalDuration)
+
+	holdDetuning := params.StartDetuning + detuningSlope*float64(params.RiseDuration)
+	fallStartDetuning := params.StartDetuning + detuningSlope*float64(params.RiseDuration+params.HoldDuration)
+
+	segments := []struct {
+		duration          int
+		ampStart, ampStop float64
+		detStart, detStop float64
+	}{
+		{params.RiseDuration, 0, params.MaxAmplitude, params.StartDetuning, holdDetuning},
+		{params.HoldDuration, params.MaxAmplitude, params.MaxAmplitude, holdDetuning, fallStartDetuning},
+		{params.FallDuration, params.MaxAmplitude, 0, fallStartDetuning, params.FinalDetuning},
+	}
+
+	for _, s := range segments {
+		amplitude := utils.Ramp{Start: s.ampStart, Stop: s.ampStop, DurationNs: s.duration}
+		detuning := utils.Ramp{Start: s.detStart, Stop: s.detStop, DurationNs: s.duration}
+		if err := seq.AddPulse("ch0", amplitude, detuning, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
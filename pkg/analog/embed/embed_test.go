@@ -0,0 +1,85 @@
+package embed
+
+import (
+	"strings"
+	"testing"
+
+	"quack/pkg/analog"
+)
+
+func TestBuildReportErrorToleranceThreshold(t *testing.T) {
+	points := []point{{x: 0, y: 0}, {x: 0, y: 10}}
+	target := map[[2]int]float64{{0, 1}: 5} // actual 10 vs target 5: relative error 1.0
+
+	params := SweepParams{}.withDefaults()
+	report := buildReport(points, target, params)
+
+	if report.RMSRelativeError != 1 {
+		t.Fatalf("RMSRelativeError = %v, want 1", report.RMSRelativeError)
+	}
+	if !hasWarningContaining(report.Warnings, "RMS relative error") {
+		t.Errorf("Warnings = %v, want one mentioning RMS relative error", report.Warnings)
+	}
+}
+
+func TestBuildReportWithinErrorTolerance(t *testing.T) {
+	points := []point{{x: 0, y: 0}, {x: 0, y: 5}}
+	target := map[[2]int]float64{{0, 1}: 5} // exact match: zero relative error
+
+	params := SweepParams{}.withDefaults()
+	report := buildReport(points, target, params)
+
+	if hasWarningContaining(report.Warnings, "RMS relative error") {
+		t.Errorf("Warnings = %v, want none mentioning RMS relative error", report.Warnings)
+	}
+}
+
+func TestBuildReportMinAtomSpacingViolation(t *testing.T) {
+	points := []point{{x: 0, y: 0}, {x: 0, y: 1}}
+	params := SweepParams{MinAtomSpacing: 4}.withDefaults()
+
+	report := buildReport(points, nil, params)
+
+	if report.MinAtomDistance != 1 {
+		t.Fatalf("MinAtomDistance = %v, want 1", report.MinAtomDistance)
+	}
+	if !hasWarningContaining(report.Warnings, "minimum atom distance") {
+		t.Errorf("Warnings = %v, want one mentioning minimum atom distance", report.Warnings)
+	}
+}
+
+func TestBuildReportMinAtomSpacingSatisfied(t *testing.T) {
+	points := []point{{x: 0, y: 0}, {x: 0, y: 10}}
+	params := SweepParams{MinAtomSpacing: 4}.withDefaults()
+
+	report := buildReport(points, nil, params)
+
+	if hasWarningContaining(report.Warnings, "minimum atom distance") {
+		t.Errorf("Warnings = %v, want none mentioning minimum atom distance", report.Warnings)
+	}
+}
+
+func TestEmbedAndBuildSequenceWarnsOnNegativeCoupling(t *testing.T) {
+	model := analog.IsingModel{
+		Spins:         2,
+		Interactions:  map[[2]int]float64{{0, 1}: -1},
+		ExternalField: map[int]float64{},
+	}
+
+	_, report, err := EmbedAndBuildSequence(model, SweepParams{RiseDuration: 100, HoldDuration: 100, FallDuration: 100})
+	if err != nil {
+		t.Fatalf("EmbedAndBuildSequence: %v", err)
+	}
+	if !hasWarningContaining(report.Warnings, "negative J_ij") {
+		t.Errorf("Warnings = %v, want one mentioning a negative J_ij", report.Warnings)
+	}
+}
+
+func hasWarningContaining(warnings []string, substr string) bool {
+	for _, w := range warnings {
+		if strings.Contains(w, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package embed
+
+import "math"
+
+// defaultC6 is the Van der Waals coefficient (in MHz * um^6) used to
+// convert an interaction strength J_ij into a target inter-atom distance
+// via the Rydberg blockade relation J_ij = C6 / r_ij^6.
+const defaultC6 = 5420000.0
+
+// targetDistance returns the atom separation that realizes interaction
+// strength j via the C6/r^6 blockade.
+func targetDistance(j, c6 float64) float64 {
+	return math.Pow(c6/math.Abs(j), 1.0/6.0)
+}
+
+// point is a 2D atom position in micrometers.
+type point struct{ x, y float64 }
+
+func (p point) distanceTo(o point) float64 {
+	dx, dy := p.x-o.x, p.y-o.y
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// initialLayout places n points evenly around a circle whose radius is
+// scaled so that neighboring points roughly span spacing; this is only a
+// starting point for stressMajorization to refine.
+func initialLayout(n int, spacing float64) []point {
+	points := make([]point, n)
+	if n == 1 {
+		return points
+	}
+	radius := spacing * float64(n) / (2 * math.Pi)
+	for i := 0; i < n; i++ {
+		theta := 2 * math.Pi * float64(i) / float64(n)
+		points[i] = point{x: radius * math.Cos(theta), y: radius * math.Sin(theta)}
+	}
+	return points
+}
+
+// stressMajorization iteratively moves points to match the target pairwise
+// distances as closely as possible, following the classical SMACOF
+// majorization update: each point is moved to the weighted average of
+// where its neighbors would place it to exactly satisfy that one target
+// distance. Unconstrained pairs (weight 0) are ignored.
+func stressMajorization(points []point, target map[[2]int]float64, iterations int) []point {
+	n := len(points)
+	for iter := 0; iter < iterations; iter++ {
+		next := make([]point, n)
+		for i := 0; i < n; i++ {
+			var sumX, sumY, weight float64
+			for j := 0; j < n; j++ {
+				if i == j {
+					continue
+				}
+				d, ok := target[orderedKey(i, j)]
+				if !ok {
+					continue
+				}
+				actual := points[i].distanceTo(points[j])
+				if actual < 1e-9 {
+					actual = 1e-9
+				}
+				// Move point i toward the position that would make its
+				// distance to j exactly d, keeping direction fixed.
+				dx, dy := points[i].x-points[j].x, points[i].y-points[j].y
+				scale := d / actual
+				sumX += points[j].x + dx*scale
+				sumY += points[j].y + dy*scale
+				weight++
+			}
+			if weight == 0 {
+				next[i] = points[i]
+				continue
+			}
+			next[i] = point{x: sumX / weight, y: sumY / weight}
+		}
+		points = next
+	}
+	return points
+}
+
+// enforceMinDistance pushes apart any pair of points closer than minDist,
+// moving each symmetrically along the line connecting them.
+func enforceMinDistance(points []point, minDist float64) []point {
+	n := len(points)
+	for pass := 0; pass < n; pass++ {
+		moved := false
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				d := points[i].distanceTo(points[j])
+				if d >= minDist || d < 1e-9 {
+					continue
+				}
+				dx, dy := points[j].x-points[i].x, points[j].y-points[i].y
+				push := (minDist - d) / 2
+				ux, uy := dx/d, dy/d
+				points[i].x -= ux * push
+				points[i].y -= uy * push
+				points[j].x += ux * push
+				points[j].y += uy * push
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	return points
+}
+
+func orderedKey(i, j int) [2]int {
+	if i > j {
+		i, j = j, i
+	}
+	return [2]int{i, j}
+}
@@ -4,4 +4,34 @@ package analog
 type Graph struct {
 	Vertices int      // Number of vertices in the graph
 	Edges    [][2]int // List of edges, where each edge is a pair of vertex indices
+
+	// VertexWeight holds a per-vertex weight, indexed by vertex. A nil
+	// VertexWeight means the graph is unweighted (every vertex weighs 1).
+	VertexWeight []float64
+
+	// EdgeWeight holds a per-edge weight, keyed like Interactions (smaller
+	// vertex index first). A nil EdgeWeight means the graph is unweighted
+	// (every edge weighs 1).
+	EdgeWeight map[[2]int]float64
+}
+
+// VertexWeightOf returns the weight of vertex i, defaulting to 1 when the
+// graph is unweighted or VertexWeight does not cover i.
+func (g Graph) VertexWeightOf(i int) float64 {
+	if i < 0 || i >= len(g.VertexWeight) {
+		return 1
+	}
+	return g.VertexWeight[i]
+}
+
+// EdgeWeightOf returns the weight of edge (i, j), defaulting to 1 when the
+// graph is unweighted or EdgeWeight has no entry for (i, j).
+func (g Graph) EdgeWeightOf(i, j int) float64 {
+	if i > j {
+		i, j = j, i
+	}
+	if w, ok := g.EdgeWeight[[2]int{i, j}]; ok {
+		return w
+	}
+	return 1
 }
@@ -0,0 +1,16 @@
+package analog
+
+// DecodeMIS decodes a ground-state basis index produced by solving a
+// CompileMIS model back into the set of included vertices. CompileMIS uses
+// a negative external field to favor spins in the +1 state for vertices in
+// the independent set, and solver.Solve represents the +1 eigenstate of Z_i
+// as bit i being unset; a vertex is therefore in the set when its bit is 0.
+func DecodeMIS(state, vertices int) []int {
+	var set []int
+	for i := 0; i < vertices; i++ {
+		if state&(1<<uint(i)) == 0 {
+			set = append(set, i)
+		}
+	}
+	return set
+}
@@ -0,0 +1,91 @@
+package analog
+
+import "fmt"
+
+// CompileQUBO converts a QUBO problem, minimizing f(x) = sum_i Q_ii x_i +
+// sum_{i<j} Q_ij x_i x_j over binary x in {0,1}^n, into an equivalent
+// IsingModel via the standard substitution x_i = (1 + s_i) / 2 (s_i in
+// {-1, +1}), matching solver.Solve's Hamiltonian convention
+// H = sum_i h_i s_i + sum_{i<j} J_ij s_i s_j:
+//
+//	h_i  = Q_ii/2 + (sum_j Q_ij)/4
+//	J_ij = Q_ij/4
+//
+// Q is keyed like IsingModel.Interactions: [2]int{i, i} for the linear
+// term Q_ii, and [2]int{i, j} with i < j for the quadratic term Q_ij. It is
+// an error for any key to reference an index outside [0, n). The second
+// return value is the constant energy offset introduced by the
+// substitution, so that the QUBO and Ising objectives agree exactly.
+func CompileQUBO(q map[[2]int]float64, n int) (IsingModel, float64, error) {
+	for key := range q {
+		if key[0] < 0 || key[0] >= n || key[1] < 0 || key[1] >= n {
+			return IsingModel{}, 0, fmt.Errorf("analog: QUBO term %v references a vertex outside [0, %d)", key, n)
+		}
+	}
+
+	ising := IsingModel{
+		Spins:         n,
+		Interactions:  make(map[[2]int]float64),
+		ExternalField: make(map[int]float64),
+	}
+
+	rowSum := make([]float64, n)
+	for key, qij := range q {
+		i, j := key[0], key[1]
+		if i == j {
+			continue
+		}
+		if i > j {
+			i, j = j, i
+		}
+		ising.Interactions[[2]int{i, j}] = qij / 4
+		rowSum[i] += qij
+		rowSum[j] += qij
+	}
+
+	var offset float64
+	for i := 0; i < n; i++ {
+		qii := q[[2]int{i, i}]
+		ising.ExternalField[i] = qii/2 + rowSum[i]/4
+		offset += qii / 2
+	}
+	for key, qij := range q {
+		if key[0] != key[1] {
+			offset += qij / 4
+		}
+	}
+
+	return ising, offset, nil
+}
+
+// IsingToQUBO is the inverse of CompileQUBO: it recovers a QUBO Q and its
+// constant offset from an IsingModel.
+func IsingToQUBO(model IsingModel) (map[[2]int]float64, float64) {
+	q := make(map[[2]int]float64)
+
+	rowSum := make([]float64, model.Spins)
+	for edge, jij := range model.Interactions {
+		qij := 4 * jij
+		i, j := edge[0], edge[1]
+		if i > j {
+			i, j = j, i
+		}
+		q[[2]int{i, j}] = qij
+		rowSum[i] += qij
+		rowSum[j] += qij
+	}
+
+	var offset float64
+	for i := 0; i < model.Spins; i++ {
+		qii := 2*model.ExternalField[i] - rowSum[i]/2
+		q[[2]int{i, i}] = qii
+		offset += qii / 2
+	}
+	for key, qij := range q {
+		if key[0] != key[1] {
+			offset += qij / 4
+		}
+	}
+
+	return q, offset
+}
@@ -0,0 +1,163 @@
+// Package solver finds ground states of analog.IsingModel instances by
+// exact diagonalization of the full 2^N x 2^N Hamiltonian. It is only
+// tractable for small N, and exists to validate the analog compiler's
+// output on small graphs rather than to solve production-sized instances.
+package solver
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+
+	"quack/pkg/analog"
+)
+
+// maxSpins bounds the solver to models whose dense Hamiltonian is still
+// manageable to build and diagonalize in memory.
+const maxSpins = 20
+
+// SolverOptions configures the exact-diagonalization solver.
+type SolverOptions struct {
+	// Transverse is the transverse field strength Gamma. When zero the
+	// Hamiltonian is purely classical (diagonal in the Z basis); when
+	// non-zero a -Gamma * sum_i X_i term is added, modeling the quantum
+	// Ising Hamiltonian used during an adiabatic sweep.
+	Transverse float64
+}
+
+// SolverResult is the outcome of diagonalizing an IsingModel.
+type SolverResult struct {
+	GroundEnergy float64
+
+	// Degeneracy counts eigenvalues within a small tolerance of
+	// GroundEnergy.
+	Degeneracy int
+
+	// Probabilities holds, for each computational basis state (indexed by
+	// its integer value, bit i set means spin i is in the |1> / -1 state),
+	// the probability mass assigned to it by the ground-state subspace.
+	// When Degeneracy > 1 this is the uniform mixture over the degenerate
+	// eigenvectors.
+	Probabilities []float64
+}
+
+// Solve builds the Hamiltonian
+//
+//	H = sum_i h_i Z_i + sum_ij J_ij Z_i Z_j - Gamma * sum_i X_i
+//
+// for model (with Z = diag(1, -1) and X = [[0, 1], [1, 0]] on each spin)
+// and diagonalizes it exactly to find its ground state(s). The sign on the
+// h_i/J_ij terms matches CompileMIS/DecodeMIS: with h negative, the ground
+// state favors spins in the +1 state (vertices included in the set), and
+// positive J_ij penalizes two adjacent spins both being +1.
+func Solve(model analog.IsingModel, opts SolverOptions) (SolverResult, error) {
+	n := model.Spins
+	if n <= 0 {
+		return SolverResult{}, fmt.Errorf("solver: model has no spins")
+	}
+	if n > maxSpins {
+		return SolverResult{}, fmt.Errorf("solver: %d spins exceeds the exact-diagonalization limit of %d", n, maxSpins)
+	}
+
+	dim := 1 << n
+	h := mat.NewSymDense(dim, nil)
+
+	// Diagonal terms: Z and Z*Z are both diagonal, so fill them directly by
+	// iterating basis states instead of forming the full Kronecker product.
+	for state := 0; state < dim; state++ {
+		var energy float64
+		for i, hi := range model.ExternalField {
+			energy += hi * zEigenvalue(state, i)
+		}
+		for edge, jij := range model.Interactions {
+			energy += jij * zEigenvalue(state, edge[0]) * zEigenvalue(state, edge[1])
+		}
+		h.SetSym(state, state, energy)
+	}
+
+	// Off-diagonal transverse field: X is not diagonal, so each -Gamma*X_i
+	// term is built as a full Kronecker product I x ... x X x ... x I and
+	// added into h element-by-element (SymDense only stores, and only
+	// exposes a setter for, the upper triangle).
+	if opts.Transverse != 0 {
+		identity := mat.NewDense(2, 2, []float64{1, 0, 0, 1})
+		x := mat.NewDense(2, 2, []float64{0, 1, 1, 0})
+		for i := 0; i < n; i++ {
+			term := kronChain(n, i, identity, x)
+			for row := 0; row < dim; row++ {
+				for col := row; col < dim; col++ {
+					if v := term.At(row, col); v != 0 {
+						h.SetSym(row, col, h.At(row, col)-opts.Transverse*v)
+					}
+				}
+			}
+		}
+	}
+
+	var eigen mat.EigenSym
+	if ok := eigen.Factorize(h, true); !ok {
+		return SolverResult{}, fmt.Errorf("solver: eigendecomposition did not converge")
+	}
+	values := eigen.Values(nil)
+	var vectors mat.Dense
+	eigen.VectorsTo(&vectors)
+
+	return groundState(dim, values, &vectors), nil
+}
+
+// zEigenvalue returns the eigenvalue of Z_i (diag(1, -1)) on basis state.
+func zEigenvalue(state, i int) float64 {
+	if state&(1<<uint(i)) != 0 {
+		return -1
+	}
+	return 1
+}
+
+// kronChain builds the n-fold Kronecker product I x ... x op x ... x I with
+// op placed at position target and identity everywhere else.
+func kronChain(n, target int, identity, op *mat.Dense) *mat.Dense {
+	result := mat.NewDense(1, 1, []float64{1})
+	for i := 0; i < n; i++ {
+		factor := identity
+		if i == target {
+			factor = op
+		}
+		rows, cols := result.Dims()
+		fr, fc := factor.Dims()
+		next := mat.NewDense(rows*fr, cols*fc, nil)
+		next.Kronecker(result, factor)
+		result = next
+	}
+	return result
+}
+
+// groundState extracts the lowest-energy eigenvalue(s) and the
+// corresponding basis-state probabilities from a real eigendecomposition.
+func groundState(dim int, eigenvalues []float64, eigenvectors *mat.Dense) SolverResult {
+	const degeneracyTolerance = 1e-9
+
+	minEnergy := eigenvalues[0]
+	for _, v := range eigenvalues {
+		if v < minEnergy {
+			minEnergy = v
+		}
+	}
+
+	degeneracy := 0
+	probabilities := make([]float64, dim)
+	for idx, v := range eigenvalues {
+		if v > minEnergy+degeneracyTolerance {
+			continue
+		}
+		degeneracy++
+		column := mat.Col(nil, idx, eigenvectors)
+		for state := 0; state < dim; state++ {
+			probabilities[state] += column[state] * column[state]
+		}
+	}
+	for state := range probabilities {
+		probabilities[state] /= float64(degeneracy)
+	}
+
+	return SolverResult{GroundEnergy: minEnergy, Degeneracy: degeneracy, Probabilities: probabilities}
+}
@@ -0,0 +1,55 @@
+package solver
+
+import (
+	"math"
+	"testing"
+
+	"quack/pkg/analog"
+)
+
+// TestSolveDecodesTrueMIS exercises the CLI's default -h/-j on a 2-vertex,
+// 1-edge graph, whose only maximum independent sets are the two singletons
+// {0} and {1}; the empty set and the full vertex set are not valid MISes.
+func TestSolveDecodesTrueMIS(t *testing.T) {
+	graph := analog.Graph{Vertices: 2, Edges: [][2]int{{0, 1}}}
+	model := analog.CompileMIS(graph, -1, 2)
+
+	result, err := Solve(model, SolverOptions{})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+
+	best := 0
+	for state, p := range result.Probabilities {
+		if p > result.Probabilities[best] {
+			best = state
+		}
+	}
+	set := analog.DecodeMIS(best, model.Spins)
+
+	if len(set) != 1 {
+		t.Fatalf("decoded MIS = %v, want a single vertex", set)
+	}
+}
+
+// TestSolveWithTransverseField exercises the off-diagonal kronChain path
+// (opts.Transverse != 0), which TestSolveDecodesTrueMIS does not reach. A
+// non-zero transverse field on a single spin with no external field or
+// interactions should still yield a well-formed, normalized probability
+// distribution over both basis states.
+func TestSolveWithTransverseField(t *testing.T) {
+	model := analog.IsingModel{Spins: 1, Interactions: map[[2]int]float64{}, ExternalField: map[int]float64{0: 0}}
+
+	result, err := Solve(model, SolverOptions{Transverse: 1})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+
+	if len(result.Probabilities) != 2 {
+		t.Fatalf("len(Probabilities) = %d, want 2", len(result.Probabilities))
+	}
+	sum := result.Probabilities[0] + result.Probabilities[1]
+	if math.Abs(sum-1) > 1e-9 {
+		t.Fatalf("probabilities sum to %.6f, want 1", sum)
+	}
+}
@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics tracks throughput and latency for one stage. All fields are
+// updated concurrently from worker goroutines; use the accessor methods
+// rather than reading fields directly.
+type Metrics struct {
+	Name string
+
+	processed    int64
+	errors       int64
+	totalLatency int64 // nanoseconds, accumulated atomically
+}
+
+// Processed returns how many items the stage has completed successfully.
+func (m *Metrics) Processed() int64 { return atomic.LoadInt64(&m.processed) }
+
+// Errors returns how many items the stage failed to process.
+func (m *Metrics) Errors() int64 { return atomic.LoadInt64(&m.errors) }
+
+// MeanLatency returns the average per-item processing latency observed so
+// far, or zero if nothing has completed yet.
+func (m *Metrics) MeanLatency() time.Duration {
+	n := m.Processed()
+	if n == 0 {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&m.totalLatency) / n)
+}
+
+func (m *Metrics) record(d time.Duration, err error) {
+	if err != nil {
+		atomic.AddInt64(&m.errors, 1)
+		return
+	}
+	atomic.AddInt64(&m.processed, 1)
+	atomic.AddInt64(&m.totalLatency, int64(d))
+}
+
+func (m *Metrics) String() string {
+	return fmt.Sprintf("%s: processed=%d errors=%d mean_latency=%s", m.Name, m.Processed(), m.Errors(), m.MeanLatency())
+}
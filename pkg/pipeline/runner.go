@@ -0,0 +1,28 @@
+package pipeline
+
+// Runner collects the Metrics of every stage in a pipeline, so the caller
+// can print one throughput summary once the whole pipeline has drained.
+type Runner struct {
+	stages []*Metrics
+}
+
+// NewRunner returns an empty Runner.
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Register adds a stage's Metrics to the runner's summary. Call this once
+// per RunStage invocation, in pipeline order.
+func (r *Runner) Register(m *Metrics) {
+	r.stages = append(r.stages, m)
+}
+
+// Summary returns one line per registered stage describing its throughput,
+// in the order stages were registered.
+func (r *Runner) Summary() []string {
+	lines := make([]string, len(r.stages))
+	for i, m := range r.stages {
+		lines[i] = m.String()
+	}
+	return lines
+}
@@ -0,0 +1,104 @@
+// Package pipeline provides generic, worker-pooled pipeline stages with
+// context-based cancellation and per-stage metrics. It replaces hand-rolled
+// goroutine/channel wiring (which is easy to get wrong, e.g. around channel
+// close ordering) with a small reusable Stage abstraction.
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Stage transforms a single In into an Out. Implementations should be safe
+// to call concurrently from multiple workers.
+type Stage[In, Out any] interface {
+	Name() string
+	Process(ctx context.Context, in In) (Out, error)
+}
+
+// StageFunc adapts a plain function to the Stage interface.
+type StageFunc[In, Out any] struct {
+	StageName string
+	Fn        func(ctx context.Context, in In) (Out, error)
+}
+
+func (f StageFunc[In, Out]) Name() string { return f.StageName }
+
+func (f StageFunc[In, Out]) Process(ctx context.Context, in In) (Out, error) {
+	return f.Fn(ctx, in)
+}
+
+// Result pairs a stage's output with any processing error, so a single
+// channel can carry both without losing track of which input failed.
+type Result[Out any] struct {
+	Value Out
+	Err   error
+}
+
+// Options configures how a stage is run.
+type Options struct {
+	// Workers is the number of goroutines concurrently calling
+	// Stage.Process. Defaults to 1.
+	Workers int
+
+	// BufferSize bounds how many results can queue before a slow
+	// downstream consumer applies backpressure to this stage's workers.
+	// Defaults to 0 (unbuffered).
+	BufferSize int
+}
+
+func (o Options) withDefaults() Options {
+	if o.Workers <= 0 {
+		o.Workers = 1
+	}
+	return o
+}
+
+// RunStage runs a worker pool of stage.Process over in and returns a
+// channel of Results plus the stage's Metrics. The returned channel is
+// closed once in is drained (or ctx is canceled) and every worker has
+// returned, so callers can range over it without missing results or
+// leaking goroutines.
+func RunStage[In, Out any](ctx context.Context, in <-chan In, stage Stage[In, Out], opts Options) (<-chan Result[Out], *Metrics) {
+	opts = opts.withDefaults()
+	out := make(chan Result[Out], opts.BufferSize)
+	metrics := &Metrics{Name: stage.Name()}
+
+	var wg sync.WaitGroup
+	wg.Add(opts.Workers)
+	for w := 0; w < opts.Workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					start := time.Now()
+					value, err := stage.Process(ctx, item)
+					metrics.record(time.Since(start), err)
+					select {
+					case out <- Result[Out]{Value: value, Err: err}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// Closing out here, after every worker has returned, is what the old
+	// hand-rolled CLI pipeline got wrong: it closed its result channel
+	// right after wg.Wait() on the producer stages, racing the goroutine
+	// that was still draining it.
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, metrics
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"quack/pkg/utils"
+)
+
+// tokenExpiryBuffer is how long before a cached OAuth2 token's reported
+// expiry it is treated as expired, so a token never fails authentication
+// mid-request due to clock skew or request latency.
+const tokenExpiryBuffer = 60 * time.Second
+
+const (
+	defaultAzureBaseURL  = "https://quantum.azure.com/v1.0"
+	azureTokenURLPattern = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+	azureScope           = "https://quantum.microsoft.com/.default"
+)
+
+// azureClient submits sequences to Azure Quantum's job API, authenticating
+// with an OAuth2 client-credentials flow against Azure Active Directory.
+// The access token is cached across requests and only refreshed once it
+// nears expiry, rather than re-fetched on every call.
+type azureClient struct {
+	cfg  Config
+	http *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func newAzureClient(cfg Config) *azureClient {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultAzureBaseURL
+	}
+	return &azureClient{cfg: cfg, http: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (c *azureClient) Submit(ctx context.Context, seq utils.SequenceBuilder) (Job, error) {
+	payload := struct {
+		Target       string                `json:"target"`
+		InputData    utils.SequenceBuilder `json:"inputData"`
+		InputParams  map[string]int        `json:"inputParams"`
+		ContainerURI string                `json:"containerUri,omitempty"`
+	}{Target: "pasqal.sim.pulser", InputData: seq, InputParams: map[string]int{"shots": c.cfg.Shots}}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Job{}, fmt.Errorf("client: azure: marshal sequence: %w", err)
+	}
+
+	var parsed struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/jobs", body, &parsed); err != nil {
+		return Job{}, fmt.Errorf("client: azure: submit: %w", err)
+	}
+	return Job{ID: parsed.ID, Backend: BackendAzureQuantum, Status: StatusPending}, nil
+}
+
+func (c *azureClient) Status(ctx context.Context, jobID string) (JobStatus, error) {
+	var parsed struct {
+		Status string `json:"status"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/jobs/"+jobID, nil, &parsed); err != nil {
+		return "", fmt.Errorf("client: azure: status: %w", err)
+	}
+	return JobStatus(strings.ToUpper(parsed.Status)), nil
+}
+
+func (c *azureClient) Results(ctx context.Context, jobID string) (Results, error) {
+	var parsed struct {
+		Counts map[string]int `json:"histogram"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/jobs/"+jobID+"/results", nil, &parsed); err != nil {
+		return Results{}, fmt.Errorf("client: azure: results: %w", err)
+	}
+	shots := 0
+	for _, n := range parsed.Counts {
+		shots += n
+	}
+	return Results{JobID: jobID, Counts: parsed.Counts, Shots: shots}, nil
+}
+
+func (c *azureClient) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return fmt.Errorf("authenticate: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// token returns a cached OAuth2 access token, fetching a new one via the
+// client-credentials flow if none is cached or the cached one is near
+// expiry.
+func (c *azureClient) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Before(c.tokenExpiry.Add(-tokenExpiryBuffer)) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.cfg.ClientID},
+		"client_secret": {c.cfg.ClientSecret},
+		"scope":         {azureScope},
+	}
+	tokenURL := fmt.Sprintf(azureTokenURLPattern, c.cfg.TenantID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var parsed struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", err
+	}
+
+	c.token = parsed.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(parsed.ExpiresIn) * time.Second)
+	return c.token, nil
+}
@@ -0,0 +1,125 @@
+// Package client submits Pulser sequences built with pkg/utils to a job
+// execution backend (Pasqal Cloud, Azure Quantum, or an in-memory mock for
+// tests) and retrieves their measurement results.
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"quack/pkg/utils"
+)
+
+// Backend identifies which execution backend a Client talks to.
+type Backend string
+
+const (
+	BackendPasqalCloud  Backend = "pasqal-cloud"
+	BackendAzureQuantum Backend = "azure-quantum"
+	BackendMock         Backend = "mock"
+)
+
+// JobStatus is the lifecycle state of a submitted job.
+type JobStatus string
+
+const (
+	StatusPending JobStatus = "PENDING"
+	StatusRunning JobStatus = "RUNNING"
+	StatusDone    JobStatus = "DONE"
+	StatusError   JobStatus = "ERROR"
+)
+
+// Job identifies a submitted sequence and its last known status.
+type Job struct {
+	ID      string
+	Backend Backend
+	Status  JobStatus
+}
+
+// Results holds the measurement outcome of a completed job.
+type Results struct {
+	JobID  string
+	Counts map[string]int // bitstring -> number of shots measured in that state
+	Shots  int
+}
+
+// Config configures authentication and connection details for a Client.
+type Config struct {
+	Backend Backend
+
+	// APIKey authenticates against Pasqal Cloud.
+	APIKey string
+
+	// ClientID, ClientSecret and TenantID authenticate against Azure
+	// Quantum via an OAuth client-credentials flow.
+	ClientID     string
+	ClientSecret string
+	TenantID     string
+
+	// BaseURL overrides the backend's default endpoint; mainly useful in
+	// tests.
+	BaseURL string
+
+	// Shots is the number of shots requested per job. Defaults to 500.
+	Shots int
+}
+
+// Client submits Pulser sequences to a job execution backend and retrieves
+// their results.
+type Client interface {
+	// Submit uploads seq and returns a handle to the created job.
+	Submit(ctx context.Context, seq utils.SequenceBuilder) (Job, error)
+	// Status fetches the current lifecycle state of a job.
+	Status(ctx context.Context, jobID string) (JobStatus, error)
+	// Results fetches the measurement counts of a completed job. Callers
+	// should only call this once Status reports StatusDone.
+	Results(ctx context.Context, jobID string) (Results, error)
+}
+
+// New constructs a Client for cfg.Backend.
+func New(cfg Config) (Client, error) {
+	if cfg.Shots <= 0 {
+		cfg.Shots = 500
+	}
+	switch cfg.Backend {
+	case BackendPasqalCloud:
+		return newPasqalClient(cfg), nil
+	case BackendAzureQuantum:
+		return newAzureClient(cfg), nil
+	case BackendMock, "":
+		return NewMockClient(), nil
+	default:
+		return nil, fmt.Errorf("client: unknown backend %q", cfg.Backend)
+	}
+}
+
+// Run submits seq, polls until the job reaches a terminal state, and
+// returns its results. pollInterval controls how often Status is checked.
+func Run(ctx context.Context, c Client, seq utils.SequenceBuilder, pollInterval time.Duration) (Results, error) {
+	job, err := c.Submit(ctx, seq)
+	if err != nil {
+		return Results{}, fmt.Errorf("client: submit: %w", err)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := c.Status(ctx, job.ID)
+		if err != nil {
+			return Results{}, fmt.Errorf("client: status: %w", err)
+		}
+		switch status {
+		case StatusDone:
+			return c.Results(ctx, job.ID)
+		case StatusError:
+			return Results{}, fmt.Errorf("client: job %s failed", job.ID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return Results{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"quack/pkg/utils"
+)
+
+func naiveTestSequence(t *testing.T, spins int) utils.SequenceBuilder {
+	t.Helper()
+	seq, err := NaiveSequence(spins)
+	if err != nil {
+		t.Fatalf("NaiveSequence: %v", err)
+	}
+	return seq
+}
+
+func TestMockClientSubmitStatusResults(t *testing.T) {
+	c := NewMockClient()
+	seq := naiveTestSequence(t, 3)
+
+	job, err := c.Submit(context.Background(), seq)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if job.Backend != BackendMock {
+		t.Errorf("job.Backend = %q, want %q", job.Backend, BackendMock)
+	}
+
+	status, err := c.Status(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if status != StatusDone {
+		t.Errorf("Status = %q, want %q", status, StatusDone)
+	}
+
+	results, err := c.Results(context.Background(), job.ID)
+	if err != nil {
+		t.Fatalf("Results: %v", err)
+	}
+	if results.Shots == 0 {
+		t.Error("Results.Shots = 0, want > 0")
+	}
+	for bitstring := range results.Counts {
+		if len(bitstring) != 3 {
+			t.Errorf("bitstring %q has length %d, want 3 (one bit per spin)", bitstring, len(bitstring))
+		}
+	}
+}
+
+func TestMockClientUnknownJob(t *testing.T) {
+	c := NewMockClient()
+	if _, err := c.Status(context.Background(), "no-such-job"); err == nil {
+		t.Error("Status on unknown job: got nil error, want one")
+	}
+	if _, err := c.Results(context.Background(), "no-such-job"); err == nil {
+		t.Error("Results on unknown job: got nil error, want one")
+	}
+}
+
+func TestRunWithMockClient(t *testing.T) {
+	c := NewMockClient()
+	seq := naiveTestSequence(t, 2)
+
+	results, err := Run(context.Background(), c, seq, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if results.Shots == 0 {
+		t.Error("Results.Shots = 0, want > 0")
+	}
+}
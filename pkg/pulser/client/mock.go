@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"quack/pkg/utils"
+)
+
+// MockClient is a local, in-memory Client. It lets callers and tests
+// exercise the submission/poll/results pipeline without network access or
+// real credentials. Submitted sequences are "executed" by sampling
+// uniformly at random over the declared register, which is enough to
+// validate the pipeline but carries no physical meaning.
+type MockClient struct {
+	mu    sync.Mutex
+	jobs  map[string]mockJob
+	count int
+}
+
+type mockJob struct {
+	seq utils.SequenceBuilder
+}
+
+// NewMockClient returns a ready-to-use MockClient.
+func NewMockClient() *MockClient {
+	return &MockClient{jobs: make(map[string]mockJob)}
+}
+
+func (m *MockClient) Submit(ctx context.Context, seq utils.SequenceBuilder) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.count++
+	id := fmt.Sprintf("mock-job-%d", m.count)
+	m.jobs[id] = mockJob{seq: seq}
+	return Job{ID: id, Backend: BackendMock, Status: StatusDone}, nil
+}
+
+func (m *MockClient) Status(ctx context.Context, jobID string) (JobStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.jobs[jobID]; !ok {
+		return "", fmt.Errorf("client: unknown job %q", jobID)
+	}
+	return StatusDone, nil
+}
+
+func (m *MockClient) Results(ctx context.Context, jobID string) (Results, error) {
+	m.mu.Lock()
+	job, ok := m.jobs[jobID]
+	m.mu.Unlock()
+	if !ok {
+		return Results{}, fmt.Errorf("client: unknown job %q", jobID)
+	}
+
+	n := len(job.seq.Register.Qubits.Positions)
+	const shots = 500
+	counts := make(map[string]int)
+	for s := 0; s < shots; s++ {
+		bits := make([]byte, n)
+		for i := range bits {
+			if rand.Intn(2) == 1 {
+				bits[i] = '1'
+			} else {
+				bits[i] = '0'
+			}
+		}
+		counts[string(bits)]++
+	}
+	return Results{JobID: jobID, Counts: counts, Shots: shots}, nil
+}
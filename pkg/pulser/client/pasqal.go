@@ -0,0 +1,99 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"quack/pkg/utils"
+)
+
+const defaultPasqalBaseURL = "https://api.pasqal.cloud/v1"
+
+// pasqalClient submits sequences to Pasqal Cloud's batch job API, using an
+// API key passed as a bearer token.
+type pasqalClient struct {
+	cfg  Config
+	http *http.Client
+}
+
+func newPasqalClient(cfg Config) *pasqalClient {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultPasqalBaseURL
+	}
+	return &pasqalClient{cfg: cfg, http: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (c *pasqalClient) Submit(ctx context.Context, seq utils.SequenceBuilder) (Job, error) {
+	payload := struct {
+		SequenceBuilder utils.SequenceBuilder `json:"sequence_builder"`
+		Shots           int                   `json:"number_of_runs"`
+	}{SequenceBuilder: seq, Shots: c.cfg.Shots}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Job{}, fmt.Errorf("client: pasqal: marshal sequence: %w", err)
+	}
+
+	var parsed struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/batches", body, &parsed); err != nil {
+		return Job{}, fmt.Errorf("client: pasqal: submit: %w", err)
+	}
+	return Job{ID: parsed.ID, Backend: BackendPasqalCloud, Status: StatusPending}, nil
+}
+
+func (c *pasqalClient) Status(ctx context.Context, jobID string) (JobStatus, error) {
+	var parsed struct {
+		Status string `json:"status"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/batches/"+jobID, nil, &parsed); err != nil {
+		return "", fmt.Errorf("client: pasqal: status: %w", err)
+	}
+	return JobStatus(parsed.Status), nil
+}
+
+func (c *pasqalClient) Results(ctx context.Context, jobID string) (Results, error) {
+	var parsed struct {
+		Counts map[string]int `json:"counts"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/batches/"+jobID+"/results", nil, &parsed); err != nil {
+		return Results{}, fmt.Errorf("client: pasqal: results: %w", err)
+	}
+	shots := 0
+	for _, n := range parsed.Counts {
+		shots += n
+	}
+	return Results{JobID: jobID, Counts: parsed.Counts, Shots: shots}, nil
+}
+
+// do issues an authenticated request against the Pasqal Cloud API and
+// decodes a JSON response into out (if non-nil).
+func (c *pasqalClient) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
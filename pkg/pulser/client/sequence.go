@@ -0,0 +1,36 @@
+package client
+
+import (
+	"fmt"
+	"math"
+
+	"quack/pkg/utils"
+)
+
+// NaiveSequence builds a minimal Pulser sequence for an IsingModel: one atom
+// per spin placed on an evenly spaced line, driven by a single global
+// Blackman/Ramp pulse. It does not attempt to realize the model's J_ij
+// couplings through atom spacing (see pkg/analog/embed for that), so it is
+// only useful for exercising the submission pipeline end-to-end.
+func NaiveSequence(spins int) (utils.SequenceBuilder, error) {
+	const spacingMicrometers = 5.0
+
+	reg := utils.Register{}
+	reg.Qubits.Positions = make(map[string][]float64, spins)
+	for i := 0; i < spins; i++ {
+		reg.Qubits.Positions[fmt.Sprintf("q%d", i)] = []float64{float64(i) * spacingMicrometers, 0}
+	}
+
+	builder := utils.SequenceBuilder{Register: reg}
+	if err := builder.DeclareChannel("ch0", "rydberg_global"); err != nil {
+		return utils.SequenceBuilder{}, err
+	}
+
+	amplitude := utils.BlackmanArea{Area: math.Pi, DurationNs: 1000}
+	detuning := utils.Ramp{Start: -5.0, Stop: 5.0, DurationNs: 1000}
+	if err := builder.AddPulse("ch0", amplitude, detuning, 0); err != nil {
+		return utils.SequenceBuilder{}, err
+	}
+
+	return builder, nil
+}
@@ -0,0 +1,66 @@
+package utils
+
+import "fmt"
+
+// DeclareChannel registers a channel named name with the given Pulser
+// channel type (e.g. "rydberg_global"). It returns an error if name is
+// already declared.
+func (s *SequenceBuilder) DeclareChannel(name, channelType string) error {
+	if _, exists := s.Channels[name]; exists {
+		return fmt.Errorf("utils: channel %q already declared", name)
+	}
+	if s.Channels == nil {
+		s.Channels = make(map[string]Channel)
+	}
+	s.Channels[name] = Channel{Type: channelType}
+	return nil
+}
+
+// AddPulse appends a pulse on channel, driven by amplitude and detuning
+// waveforms of equal duration, at the given phase (radians). It returns an
+// error if channel was not declared or the two waveforms' durations
+// disagree.
+func (s *SequenceBuilder) AddPulse(channel string, amplitude, detuning Waveform, phase float64) error {
+	if _, ok := s.Channels[channel]; !ok {
+		return fmt.Errorf("utils: channel %q not declared", channel)
+	}
+	if amplitude.Duration() != detuning.Duration() {
+		return fmt.Errorf("utils: amplitude duration %d does not match detuning duration %d", amplitude.Duration(), detuning.Duration())
+	}
+	s.Pulses = append(s.Pulses, Pulse{Channel: channel, Amplitude: amplitude, Detuning: detuning, Phase: phase})
+	return nil
+}
+
+// Measure records the measurement basis for the sequence (e.g.
+// "ground-rydberg"). Pulser sequences measure once, at the end, so calling
+// this twice is an error.
+func (s *SequenceBuilder) Measure(basis string) error {
+	if s.Measurement != "" {
+		return fmt.Errorf("utils: sequence already has measurement basis %q", s.Measurement)
+	}
+	s.Measurement = basis
+	return nil
+}
+
+// Validate checks that every pulse references a declared channel, that
+// each waveform satisfies limits, and that the sequence's total duration
+// does not exceed limits.MaxTotalDuration (when set).
+func (s *SequenceBuilder) Validate(limits DeviceLimits) error {
+	var total int
+	for _, p := range s.Pulses {
+		if _, ok := s.Channels[p.Channel]; !ok {
+			return fmt.Errorf("utils: pulse references undeclared channel %q", p.Channel)
+		}
+		if err := p.Amplitude.Validate(limits); err != nil {
+			return fmt.Errorf("utils: amplitude on channel %q: %w", p.Channel, err)
+		}
+		if err := p.Detuning.Validate(limits); err != nil {
+			return fmt.Errorf("utils: detuning on channel %q: %w", p.Channel, err)
+		}
+		total += p.Amplitude.Duration()
+	}
+	if limits.MaxTotalDuration > 0 && total > limits.MaxTotalDuration {
+		return fmt.Errorf("utils: total sequence duration %d exceeds device limit %d", total, limits.MaxTotalDuration)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package utils
+
+import "testing"
+
+func newTestSequence(t *testing.T) SequenceBuilder {
+	t.Helper()
+	s := SequenceBuilder{}
+	if err := s.DeclareChannel("ch0", "rydberg_global"); err != nil {
+		t.Fatalf("DeclareChannel: %v", err)
+	}
+	if err := s.AddPulse("ch0", Constant{Value: 1, DurationNs: 100}, Constant{Value: 0, DurationNs: 100}, 0); err != nil {
+		t.Fatalf("AddPulse: %v", err)
+	}
+	return s
+}
+
+func TestSequenceBuilderValidateUndeclaredChannel(t *testing.T) {
+	s := newTestSequence(t)
+	s.Pulses[0].Channel = "ch1"
+	if err := s.Validate(DeviceLimits{}); err == nil {
+		t.Error("Validate with an undeclared channel: got nil error, want one")
+	}
+}
+
+func TestSequenceBuilderValidatePropagatesWaveformError(t *testing.T) {
+	s := newTestSequence(t)
+	if err := s.Validate(DeviceLimits{MaxAmplitude: 0.5}); err == nil {
+		t.Error("Validate with amplitude above the limit: got nil error, want one")
+	}
+}
+
+func TestSequenceBuilderValidateMaxTotalDuration(t *testing.T) {
+	s := newTestSequence(t)
+	if err := s.Validate(DeviceLimits{MaxTotalDuration: 50}); err == nil {
+		t.Error("Validate with total duration above the limit: got nil error, want one")
+	}
+	if err := s.Validate(DeviceLimits{MaxTotalDuration: 100}); err != nil {
+		t.Errorf("Validate with total duration at the limit: got %v, want nil", err)
+	}
+}
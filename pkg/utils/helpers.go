@@ -1,18 +1,10 @@
 package utils
 
-import (q
+import (
 	"encoding/json"
+	"math"
 )
 
-// Waveform represents a waveform in the Pulser sequence.
-type Waveform struct {
-	Type     string  `json:"type"`
-	Duration int     `json:"duration"`
-	Start    float32 `json:"start,omitempty"`
-	Stop     float32 `json:"stop,omitempty"`
-	Area     float32 `json:"area,omitempty"` // For Blackman, area is like integral, but here we use amplitude as per example
-}
-
 // Pulse represents a pulse in the sequence.
 type Pulse struct {
 	Channel   string   `json:"channel"`
@@ -33,12 +25,14 @@ type Register struct {
 	} `json:"qubits"`
 }
 
-// SequenceBuilder is the core structure for the Pulser sequence abstract representation.
+// SequenceBuilder is the core structure for the Pulser sequence abstract
+// representation. Build one with DeclareChannel and AddPulse rather than
+// filling Pulses directly, so channel references stay consistent.
 type SequenceBuilder struct {
-	Channels map[string]Channel `json:"channels"`
-	Pulses   []Pulse            `json:"pulses"`
-	Register Register           `json:"register"`
-	// Additional fields can be added as needed, e.g., variables, measurement, etc.
+	Channels    map[string]Channel `json:"channels"`
+	Pulses      []Pulse            `json:"pulses"`
+	Register    Register           `json:"register"`
+	Measurement string             `json:"measurement,omitempty"`
 }
 
 // InputData wraps the sequence builder for submission (as per Azure/Pasqal format).
@@ -52,44 +46,24 @@ type InputData struct {
 // - A Rydberg global channel "ch0".
 // - A single pulse with Blackman amplitude and Ramp detuning.
 func CreateSimplePulserSequence() (string, error) {
-	// Define the register
 	reg := Register{}
 	reg.Qubits.Positions = map[string][]float64{
 		"q0": {0.0, 0.0},
 	}
 
-	// Define channels
-	channels := map[string]Channel{
-		"ch0": {Type: "rydberg_global"},
-	}
-
-	// Define a simple pulse
-	pulse := Pulse{
-		Channel: "ch0",
-		Amplitude: Waveform{
-			Type:     "blackman",
-			Duration: 1000,
-			Area:     3.141592653589793, // Approximate pi for amplitude area
-		},
-		Detuning: Waveform{
-			Type:     "ramp",
-			Duration: 1000,
-			Start:    -5.0,
-			Stop:     5.0,
-		},
-		Phase: 0.0,
+	builder := SequenceBuilder{Register: reg}
+	if err := builder.DeclareChannel("ch0", "rydberg_global"); err != nil {
+		return "", err
 	}
 
-	// Build the sequence
-	builder := SequenceBuilder{
-		Channels: channels,
-		Pulses:   []Pulse{pulse},
-		Register: reg,
+	amplitude := BlackmanArea{Area: math.Pi, DurationNs: 1000}
+	detuning := Ramp{Start: -5.0, Stop: 5.0, DurationNs: 1000}
+	if err := builder.AddPulse("ch0", amplitude, detuning, 0.0); err != nil {
+		return "", err
 	}
 
 	inputData := InputData{SequenceBuilder: builder}
 
-	// Marshal to JSON
 	jsonData, err := json.MarshalIndent(inputData, "", "  ")
 	if err != nil {
 		return "", err
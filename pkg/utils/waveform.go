@@ -0,0 +1,377 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Waveform describes a time-varying pulse envelope (amplitude or detuning)
+// over its Duration. Concrete implementations below cover the waveform
+// shapes Pulser sequences commonly need; MarshalJSON on each produces the
+// shape expected by the Pulser schema.
+type Waveform interface {
+	// Sample returns the waveform's value at time t, in nanoseconds from
+	// the start of the waveform.
+	Sample(t int) float64
+	// Duration is the waveform's total length in nanoseconds.
+	Duration() int
+	// Validate enforces device limits: maximum amplitude and a duration
+	// that is a multiple of the clock period. Ramp and Interpolated (and
+	// Composite, through its segments) also enforce maximum slope;
+	// Blackman, BlackmanArea and CustomSamples do not check slope.
+	Validate(limits DeviceLimits) error
+	MarshalJSON() ([]byte, error)
+}
+
+// DeviceLimits bounds what a Waveform, Pulse, or SequenceBuilder may ask
+// hardware to do. A zero value in any field means that limit is not
+// enforced.
+type DeviceLimits struct {
+	MaxAmplitude     float64 // rad/us
+	MaxSlope         float64 // max |d(value)/dt|, value-units per ns
+	ClockPeriod      int     // duration must be a multiple of this, in ns
+	MaxTotalDuration int     // ns, checked by SequenceBuilder.Validate
+}
+
+func validateDuration(duration int, limits DeviceLimits) error {
+	if duration <= 0 {
+		return fmt.Errorf("duration must be positive, got %d", duration)
+	}
+	if limits.ClockPeriod > 0 && duration%limits.ClockPeriod != 0 {
+		return fmt.Errorf("duration %d is not a multiple of the clock period %d", duration, limits.ClockPeriod)
+	}
+	return nil
+}
+
+func validateAmplitude(value float64, limits DeviceLimits) error {
+	if limits.MaxAmplitude > 0 && math.Abs(value) > limits.MaxAmplitude {
+		return fmt.Errorf("value %.3f exceeds max amplitude %.3f", value, limits.MaxAmplitude)
+	}
+	return nil
+}
+
+func validateSlope(start, stop float64, duration int, limits DeviceLimits) error {
+	if limits.MaxSlope <= 0 || duration <= 0 {
+		return nil
+	}
+	slope := math.Abs(stop-start) / float64(duration)
+	if slope > limits.MaxSlope {
+		return fmt.Errorf("slope %.6f exceeds device limit %.6f", slope, limits.MaxSlope)
+	}
+	return nil
+}
+
+// Constant holds a waveform at a fixed value for its whole duration.
+type Constant struct {
+	Value      float64
+	DurationNs int
+}
+
+func (c Constant) Sample(t int) float64 { return c.Value }
+func (c Constant) Duration() int        { return c.DurationNs }
+
+func (c Constant) Validate(limits DeviceLimits) error {
+	if err := validateDuration(c.DurationNs, limits); err != nil {
+		return err
+	}
+	return validateAmplitude(c.Value, limits)
+}
+
+func (c Constant) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string  `json:"type"`
+		Duration int     `json:"duration"`
+		Value    float64 `json:"value"`
+	}{"constant", c.DurationNs, c.Value})
+}
+
+// Ramp linearly interpolates between Start and Stop over its duration.
+type Ramp struct {
+	Start, Stop float64
+	DurationNs  int
+}
+
+func (r Ramp) Duration() int { return r.DurationNs }
+
+func (r Ramp) Sample(t int) float64 {
+	if r.DurationNs <= 0 {
+		return r.Start
+	}
+	frac := clampFraction(float64(t) / float64(r.DurationNs))
+	return r.Start + (r.Stop-r.Start)*frac
+}
+
+func (r Ramp) Validate(limits DeviceLimits) error {
+	if err := validateDuration(r.DurationNs, limits); err != nil {
+		return err
+	}
+	if err := validateAmplitude(r.Start, limits); err != nil {
+		return err
+	}
+	if err := validateAmplitude(r.Stop, limits); err != nil {
+		return err
+	}
+	return validateSlope(r.Start, r.Stop, r.DurationNs, limits)
+}
+
+func (r Ramp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string  `json:"type"`
+		Duration int     `json:"duration"`
+		Start    float64 `json:"start"`
+		Stop     float64 `json:"stop"`
+	}{"ramp", r.DurationNs, r.Start, r.Stop})
+}
+
+// blackmanWindow evaluates the standard 3-term Blackman window at frac (in
+// [0, 1]); it peaks at 1.0 when frac == 0.5 and is 0 at both ends.
+func blackmanWindow(frac float64) float64 {
+	return 0.42 - 0.5*math.Cos(2*math.Pi*frac) + 0.08*math.Cos(4*math.Pi*frac)
+}
+
+// Blackman shapes a pulse as a Blackman window reaching PeakAmplitude at
+// its midpoint. Use BlackmanArea instead when the pulse area (its time
+// integral), rather than its peak, is the controlled quantity.
+type Blackman struct {
+	PeakAmplitude float64
+	DurationNs    int
+}
+
+func (b Blackman) Duration() int { return b.DurationNs }
+
+func (b Blackman) Sample(t int) float64 {
+	if b.DurationNs <= 0 {
+		return 0
+	}
+	return b.PeakAmplitude * blackmanWindow(clampFraction(float64(t)/float64(b.DurationNs)))
+}
+
+func (b Blackman) Validate(limits DeviceLimits) error {
+	if err := validateDuration(b.DurationNs, limits); err != nil {
+		return err
+	}
+	return validateAmplitude(b.PeakAmplitude, limits)
+}
+
+func (b Blackman) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type          string  `json:"type"`
+		Duration      int     `json:"duration"`
+		PeakAmplitude float64 `json:"peak_amplitude"`
+	}{"blackman_peak", b.DurationNs, b.PeakAmplitude})
+}
+
+// BlackmanArea shapes a pulse as a Blackman window scaled so that its time
+// integral equals Area (in rad), matching Pulser's canonical
+// BlackmanWaveform(duration, area) constructor.
+type BlackmanArea struct {
+	Area       float64
+	DurationNs int
+}
+
+// peakAmplitude derives the window's peak value from the requested area:
+// the Blackman window's mean value over one period is 0.42 (its two cosine
+// terms integrate to zero), so a window lasting DurationNs/1000
+// microseconds integrates to Area when its peak is Area / (0.42 * that).
+func (b BlackmanArea) peakAmplitude() float64 {
+	if b.DurationNs <= 0 {
+		return 0
+	}
+	durationUs := float64(b.DurationNs) / 1000.0
+	return b.Area / (0.42 * durationUs)
+}
+
+func (b BlackmanArea) Duration() int { return b.DurationNs }
+
+func (b BlackmanArea) Sample(t int) float64 {
+	if b.DurationNs <= 0 {
+		return 0
+	}
+	return b.peakAmplitude() * blackmanWindow(clampFraction(float64(t)/float64(b.DurationNs)))
+}
+
+func (b BlackmanArea) Validate(limits DeviceLimits) error {
+	if err := validateDuration(b.DurationNs, limits); err != nil {
+		return err
+	}
+	return validateAmplitude(b.peakAmplitude(), limits)
+}
+
+func (b BlackmanArea) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string  `json:"type"`
+		Duration int     `json:"duration"`
+		Area     float64 `json:"area"`
+	}{"blackman", b.DurationNs, b.Area})
+}
+
+// Interpolated linearly interpolates between an explicit set of (time,
+// value) control points, with Times[0] == 0 and strictly increasing.
+type Interpolated struct {
+	Times      []int
+	Values     []float64
+	DurationNs int
+}
+
+func (w Interpolated) Duration() int { return w.DurationNs }
+
+func (w Interpolated) Sample(t int) float64 {
+	if len(w.Times) == 0 {
+		return 0
+	}
+	if t <= w.Times[0] {
+		return w.Values[0]
+	}
+	for i := 1; i < len(w.Times); i++ {
+		if t <= w.Times[i] {
+			span := float64(w.Times[i] - w.Times[i-1])
+			if span <= 0 {
+				return w.Values[i]
+			}
+			frac := float64(t-w.Times[i-1]) / span
+			return w.Values[i-1] + (w.Values[i]-w.Values[i-1])*frac
+		}
+	}
+	return w.Values[len(w.Values)-1]
+}
+
+func (w Interpolated) Validate(limits DeviceLimits) error {
+	if len(w.Times) != len(w.Values) {
+		return fmt.Errorf("interpolated waveform has %d times but %d values", len(w.Times), len(w.Values))
+	}
+	if len(w.Times) < 2 {
+		return fmt.Errorf("interpolated waveform needs at least 2 points")
+	}
+	if err := validateDuration(w.DurationNs, limits); err != nil {
+		return err
+	}
+	for i, v := range w.Values {
+		if err := validateAmplitude(v, limits); err != nil {
+			return err
+		}
+		if i == 0 {
+			continue
+		}
+		if w.Times[i] <= w.Times[i-1] {
+			return fmt.Errorf("interpolated waveform times must be strictly increasing")
+		}
+		if err := validateSlope(w.Values[i-1], v, w.Times[i]-w.Times[i-1], limits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w Interpolated) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		Duration int       `json:"duration"`
+		Times    []int     `json:"times"`
+		Values   []float64 `json:"values"`
+	}{"interpolated", w.DurationNs, w.Times, w.Values})
+}
+
+// CustomSamples holds an arbitrary, evenly spaced list of samples over the
+// waveform's duration, for shapes none of the other Waveform types capture.
+type CustomSamples struct {
+	Samples    []float64
+	DurationNs int
+}
+
+func (w CustomSamples) Duration() int { return w.DurationNs }
+
+func (w CustomSamples) Sample(t int) float64 {
+	if len(w.Samples) == 0 || w.DurationNs <= 0 {
+		return 0
+	}
+	idx := t * len(w.Samples) / w.DurationNs
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(w.Samples) {
+		idx = len(w.Samples) - 1
+	}
+	return w.Samples[idx]
+}
+
+func (w CustomSamples) Validate(limits DeviceLimits) error {
+	if len(w.Samples) == 0 {
+		return fmt.Errorf("custom waveform has no samples")
+	}
+	if err := validateDuration(w.DurationNs, limits); err != nil {
+		return err
+	}
+	for _, v := range w.Samples {
+		if err := validateAmplitude(v, limits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w CustomSamples) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		Duration int       `json:"duration"`
+		Samples  []float64 `json:"samples"`
+	}{"custom", w.DurationNs, w.Samples})
+}
+
+// Composite concatenates several waveforms end-to-end into one, so a pulse
+// can be built out of more than one shape (e.g. ramp up, hold, ramp down).
+type Composite struct {
+	Segments []Waveform
+}
+
+func (c Composite) Duration() int {
+	total := 0
+	for _, s := range c.Segments {
+		total += s.Duration()
+	}
+	return total
+}
+
+func (c Composite) Sample(t int) float64 {
+	for _, s := range c.Segments {
+		if t < s.Duration() {
+			return s.Sample(t)
+		}
+		t -= s.Duration()
+	}
+	if len(c.Segments) == 0 {
+		return 0
+	}
+	last := c.Segments[len(c.Segments)-1]
+	return last.Sample(last.Duration())
+}
+
+func (c Composite) Validate(limits DeviceLimits) error {
+	if len(c.Segments) == 0 {
+		return fmt.Errorf("composite waveform has no segments")
+	}
+	for i, s := range c.Segments {
+		if err := s.Validate(limits); err != nil {
+			return fmt.Errorf("segment %d: %w", i, err)
+		}
+	}
+	return validateDuration(c.Duration(), limits)
+}
+
+func (c Composite) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type      string     `json:"type"`
+		Duration  int        `json:"duration"`
+		Waveforms []Waveform `json:"waveforms"`
+	}{"composite", c.Duration(), c.Segments})
+}
+
+func clampFraction(frac float64) float64 {
+	if frac < 0 {
+		return 0
+	}
+	if frac > 1 {
+		return 1
+	}
+	return frac
+}
@@ -0,0 +1,74 @@
+package utils
+
+import "testing"
+
+func TestValidateDurationMultipleOfClockPeriod(t *testing.T) {
+	w := Constant{Value: 1, DurationNs: 100}
+	if err := w.Validate(DeviceLimits{ClockPeriod: 3}); err == nil {
+		t.Error("Validate with duration not a multiple of the clock period: got nil error, want one")
+	}
+	if err := w.Validate(DeviceLimits{ClockPeriod: 5}); err != nil {
+		t.Errorf("Validate with duration a multiple of the clock period: got %v, want nil", err)
+	}
+}
+
+func TestValidateAmplitudeExceedsLimit(t *testing.T) {
+	w := Constant{Value: 10, DurationNs: 100}
+	if err := w.Validate(DeviceLimits{MaxAmplitude: 5}); err == nil {
+		t.Error("Validate with amplitude above the limit: got nil error, want one")
+	}
+	if err := w.Validate(DeviceLimits{MaxAmplitude: 20}); err != nil {
+		t.Errorf("Validate with amplitude within the limit: got %v, want nil", err)
+	}
+}
+
+func TestRampValidateSlopeExceedsLimit(t *testing.T) {
+	r := Ramp{Start: 0, Stop: 10, DurationNs: 100} // slope = 0.1
+	if err := r.Validate(DeviceLimits{MaxSlope: 0.05}); err == nil {
+		t.Error("Validate with slope above the limit: got nil error, want one")
+	}
+	if err := r.Validate(DeviceLimits{MaxSlope: 0.2}); err != nil {
+		t.Errorf("Validate with slope within the limit: got %v, want nil", err)
+	}
+}
+
+func TestInterpolatedValidateMismatchedLengths(t *testing.T) {
+	w := Interpolated{Times: []int{0, 10}, Values: []float64{0}, DurationNs: 10}
+	if err := w.Validate(DeviceLimits{}); err == nil {
+		t.Error("Validate with mismatched Times/Values lengths: got nil error, want one")
+	}
+}
+
+func TestInterpolatedValidateNonIncreasingTimes(t *testing.T) {
+	w := Interpolated{Times: []int{0, 5, 5}, Values: []float64{0, 1, 2}, DurationNs: 10}
+	if err := w.Validate(DeviceLimits{}); err == nil {
+		t.Error("Validate with non-increasing times: got nil error, want one")
+	}
+}
+
+func TestCustomSamplesValidateNoSamples(t *testing.T) {
+	w := CustomSamples{DurationNs: 10}
+	if err := w.Validate(DeviceLimits{}); err == nil {
+		t.Error("Validate with no samples: got nil error, want one")
+	}
+}
+
+func TestCompositeValidatePropagatesSegmentError(t *testing.T) {
+	c := Composite{Segments: []Waveform{
+		Constant{Value: 1, DurationNs: 100},
+		Ramp{Start: 0, Stop: 100, DurationNs: 10}, // slope = 10
+	}}
+	if err := c.Validate(DeviceLimits{MaxSlope: 1}); err == nil {
+		t.Error("Validate with an invalid segment: got nil error, want one")
+	}
+	if err := c.Validate(DeviceLimits{}); err != nil {
+		t.Errorf("Validate with no limits: got %v, want nil", err)
+	}
+}
+
+func TestCompositeValidateNoSegments(t *testing.T) {
+	c := Composite{}
+	if err := c.Validate(DeviceLimits{}); err == nil {
+		t.Error("Validate with no segments: got nil error, want one")
+	}
+}